@@ -0,0 +1,46 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+// Package cache holds the interfaces used to plug external token cache storage
+// (e.g. a file, a distributed cache, a database) into base.Client via
+// base.WithCacheAccessor.
+package cache
+
+import "context"
+
+// Marshaler is implemented by a cache that can serialize itself to bytes for storage.
+type Marshaler interface {
+	Marshal() ([]byte, error)
+}
+
+// Unmarshaler is implemented by a cache that can restore itself from bytes read from storage.
+type Unmarshaler interface {
+	Unmarshal([]byte) error
+}
+
+// ExportReplace lets an application plug single-blob cache persistence into base.Client,
+// as shown in the TokenCache example. Replace and Export always operate on the entire
+// in-memory cache, so an implementation backing many tenants or accounts pays for a full
+// (de)serialization of every token on every call. Implementations that can partition their
+// storage should use PartitionedCacheAccessor instead.
+type ExportReplace interface {
+	Replace(cache Unmarshaler, key string)
+	Export(cache Marshaler, key string)
+}
+
+// PartitionedCacheAccessor lets an application persist the cache per logical partition,
+// e.g. per tenant for the app cache or per account for the user cache, instead of
+// (de)serializing the whole cache on every call. base.Client derives suggestedPartitionKey
+// from the request's tenant and home account ID (app cache) or client ID and scope set
+// (user cache); an implementation may use it verbatim, fold it into its own scheme, or
+// ignore it and fall back to a single partition.
+type PartitionedCacheAccessor interface {
+	// Replace returns the raw blob stored for the in-memory cache named by key under the
+	// partition referenced by suggestedPartitionKey, or nil if that partition is empty.
+	// base.Client unmarshals the blob into that partition itself; a nil blob leaves the
+	// partition empty, exactly as a blob holding zero tokens does.
+	Replace(ctx context.Context, key, suggestedPartitionKey string) ([]byte, error)
+	// Export persists blob, the serialized contents of the in-memory cache named by key,
+	// under partitionKey.
+	Export(ctx context.Context, key, partitionKey string, blob []byte) error
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+// Package cloud holds the endpoint configuration for a national or sovereign cloud, so that
+// public.Client and confidential.Client aren't hardcoded to the public Azure cloud's
+// instance discovery host.
+package cloud
+
+// Configuration describes the endpoints MSAL needs for a specific cloud: the AAD authority
+// host used to build authority URLs, the instance discovery endpoint used to validate and
+// cache authority metadata, and the audience used when requesting tokens. Applications
+// targeting Azure Government, Azure China, or an air-gapped/custom cloud (including B2C
+// tenants that don't support instance discovery) pass one of these to base.WithCloud instead
+// of relying on the AzurePublic default.
+type Configuration struct {
+	// Name identifies the cloud, e.g. "AzurePublic". base.Client folds it into cache
+	// partition keys so that caches from different clouds never collide.
+	Name string
+
+	// ActiveDirectoryAuthorityHost is the base URL used to build authority URLs, e.g.
+	// "https://login.microsoftonline.com/".
+	ActiveDirectoryAuthorityHost string
+
+	// InstanceDiscoveryEndpoint is queried to validate the configured authority and to
+	// discover its aliases. Leave it empty and set DisableInstanceDiscovery for clouds that
+	// don't expose this endpoint.
+	InstanceDiscoveryEndpoint string
+
+	// TokenAudience identifies this cloud to Microsoft Graph and other resources that key
+	// access policy off the issuing cloud.
+	TokenAudience string
+
+	// DisableInstanceDiscovery skips instance discovery and authority validation entirely.
+	// Required for air-gapped clouds and B2C tenants, which don't support the endpoint.
+	DisableInstanceDiscovery bool
+}
+
+var (
+	// AzurePublic is the public, global Azure cloud. It's the default when no Configuration
+	// is supplied.
+	AzurePublic = Configuration{
+		Name:                         "AzurePublic",
+		ActiveDirectoryAuthorityHost: "https://login.microsoftonline.com/",
+		InstanceDiscoveryEndpoint:    "https://login.microsoftonline.com/common/discovery/instance",
+		TokenAudience:                "https://management.azure.com/",
+	}
+
+	// AzureGovernment is the Azure Government cloud.
+	AzureGovernment = Configuration{
+		Name:                         "AzureGovernment",
+		ActiveDirectoryAuthorityHost: "https://login.microsoftonline.us/",
+		InstanceDiscoveryEndpoint:    "https://login.microsoftonline.us/common/discovery/instance",
+		TokenAudience:                "https://management.usgovcloudapi.net/",
+	}
+
+	// AzureChina is the Azure China cloud, operated by 21Vianet.
+	AzureChina = Configuration{
+		Name:                         "AzureChina",
+		ActiveDirectoryAuthorityHost: "https://login.chinacloudapi.cn/",
+		InstanceDiscoveryEndpoint:    "https://login.chinacloudapi.cn/common/discovery/instance",
+		TokenAudience:                "https://management.chinacloudapi.cn/",
+	}
+)
+
+// Custom builds a Configuration for an air-gapped or otherwise non-standard cloud that
+// doesn't support instance discovery. authorityHost is the base URL used to build authority
+// URLs, e.g. "https://login.contoso-sovereign.example/". name identifies the cloud in cache
+// partition keys, e.g. "contoso-sovereign".
+func Custom(name, authorityHost, tokenAudience string) Configuration {
+	return Configuration{
+		Name:                         name,
+		ActiveDirectoryAuthorityHost: authorityHost,
+		TokenAudience:                tokenAudience,
+		DisableInstanceDiscovery:     true,
+	}
+}
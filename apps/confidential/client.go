@@ -0,0 +1,46 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+// Package confidential provides a client for authentication flows that run in a trusted
+// environment able to hold a client secret, certificate, or assertion.
+package confidential
+
+import (
+	"context"
+
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/base"
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth"
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth/ops/accesstokens"
+)
+
+// Client is a confidential client application.
+type Client struct {
+	base base.Client
+	cred Credential
+}
+
+// New creates a Client for clientID, authenticating against authorityURL with cred.
+func New(clientID, authorityURL string, cred Credential, oauthClient *oauth.Client, opts ...base.Option) (Client, error) {
+	b, err := base.New(clientID, authorityURL, oauthClient, opts...)
+	if err != nil {
+		return Client{}, err
+	}
+	return Client{base: b, cred: cred}, nil
+}
+
+// AcquireTokenByCredential acquires a token for the client itself (the client credentials
+// grant), using the app cache. The request credential is built from cred immediately before
+// the call, via cred's assertion callback if it has one, so a short-lived federated
+// credential (e.g. workload identity) is always fresh rather than cached across calls.
+func (c Client) AcquireTokenByCredential(ctx context.Context, scopes []string) (base.AuthResult, error) {
+	internalCred, err := c.cred.toInternal(ctx, c.base.AuthParams.ClientID, c.base.AuthParams.Endpoints.TokenEndpoint)
+	if err != nil {
+		return base.AuthResult{}, err
+	}
+	return c.base.AcquireTokenSilent(ctx, base.AcquireTokenSilentParameters{
+		Scopes:      scopes,
+		RequestType: accesstokens.ATConfidential,
+		Credential:  internalCred,
+		IsAppCache:  true,
+	})
+}
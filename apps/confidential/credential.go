@@ -0,0 +1,75 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package confidential
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth/ops/accesstokens"
+)
+
+// AssertionRequestOptions contains information a Credential's assertion callback may need to
+// produce a valid client assertion.
+type AssertionRequestOptions struct {
+	// ClientID is the application's client ID.
+	ClientID string
+	// TokenEndpoint is the endpoint against which the assertion will be redeemed, which an
+	// assertion provider may need in order to set the assertion's audience.
+	TokenEndpoint string
+}
+
+// Credential represents a client secret or assertion used to authenticate a confidential
+// client when requesting tokens.
+type Credential struct {
+	secret            string
+	assertionCallback func(context.Context, AssertionRequestOptions) (string, error)
+}
+
+// NewCredFromSecret creates a Credential from a client secret.
+func NewCredFromSecret(secret string) (Credential, error) {
+	if secret == "" {
+		return Credential{}, fmt.Errorf("secret can't be empty string")
+	}
+	return Credential{secret: secret}, nil
+}
+
+// NewCredFromAssertion creates a Credential from a static, pre-signed client assertion (JWT).
+// Prefer NewCredFromAssertionCallback when the assertion has a short lifetime, e.g. one
+// issued by a workload identity provider, so MSAL can fetch a fresh one as needed instead of
+// reusing an expired JWT.
+func NewCredFromAssertion(assertion string) (Credential, error) {
+	if assertion == "" {
+		return Credential{}, fmt.Errorf("assertion can't be empty string")
+	}
+	return Credential{assertionCallback: func(context.Context, AssertionRequestOptions) (string, error) {
+		return assertion, nil
+	}}, nil
+}
+
+// NewCredFromAssertionCallback creates a Credential that invokes callback to obtain a client
+// assertion on every Client.AcquireTokenByCredential call, including calls the cache
+// satisfies without a token request. This is the pattern used
+// for workload identity federation, e.g. a Kubernetes projected service account token or a
+// GitHub Actions OIDC token: the assertion is short-lived, so it must be fetched fresh rather
+// than cached by the caller.
+func NewCredFromAssertionCallback(callback func(context.Context, AssertionRequestOptions) (string, error)) (Credential, error) {
+	if callback == nil {
+		return Credential{}, fmt.Errorf("callback can't be nil")
+	}
+	return Credential{assertionCallback: callback}, nil
+}
+
+// toInternal builds the internal accesstokens.Credential used to authenticate a token
+// request, invoking the assertion callback, if any, to get a fresh assertion.
+func (c Credential) toInternal(ctx context.Context, clientID, tokenEndpoint string) (*accesstokens.Credential, error) {
+	if c.assertionCallback != nil {
+		assertion, err := c.assertionCallback(ctx, AssertionRequestOptions{ClientID: clientID, TokenEndpoint: tokenEndpoint})
+		if err != nil {
+			return nil, fmt.Errorf("assertion callback: %w", err)
+		}
+		return &accesstokens.Credential{Assertion: assertion, AssertionType: accesstokens.AssertionTypeJWTBearer}, nil
+	}
+	return &accesstokens.Credential{Secret: c.secret}, nil
+}
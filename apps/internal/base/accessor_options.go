@@ -0,0 +1,67 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package base
+
+import (
+	"context"
+
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/cache"
+)
+
+// WithCacheAccessor registers a single-blob cache.ExportReplace accessor, as used by the
+// TokenCache example, adapting it into a single-partition cache.PartitionedCacheAccessor so
+// applications already using it keep working. Every AcquireTokenSilent call (de)serializes
+// the whole cache through it, same as before this adapter existed. Prefer
+// WithPartitionedCacheAccessor for caches that scale with the number of tenants or accounts.
+func WithCacheAccessor(accessor cache.ExportReplace) Option {
+	return func(c *Client) {
+		c.partitionedAccessor = singlePartitionAccessor{accessor: accessor}
+	}
+}
+
+// WithPartitionedCacheAccessor registers a cache.PartitionedCacheAccessor so that
+// AcquireTokenSilent (de)serializes only the partition relevant to each request — derived
+// from tenant and home account ID for the app cache, or client ID and scopes for the user
+// cache — instead of the whole cache.
+func WithPartitionedCacheAccessor(accessor cache.PartitionedCacheAccessor) Option {
+	return func(c *Client) {
+		c.partitionedAccessor = accessor
+	}
+}
+
+// singlePartitionAccessor adapts a single-blob cache.ExportReplace into a
+// cache.PartitionedCacheAccessor with exactly one partition, named by key rather than
+// suggestedPartitionKey/partitionKey, so the whole cache round-trips regardless of which
+// partition base.Client asks for.
+type singlePartitionAccessor struct {
+	accessor cache.ExportReplace
+}
+
+// Replace calls the wrapped ExportReplace.Replace, capturing the blob it passes to Unmarshal.
+func (a singlePartitionAccessor) Replace(ctx context.Context, key, suggestedPartitionKey string) ([]byte, error) {
+	var blob []byte
+	a.accessor.Replace(unmarshalFunc(func(b []byte) error {
+		blob = b
+		return nil
+	}), key)
+	return blob, nil
+}
+
+// Export calls the wrapped ExportReplace.Export, handing it blob via Marshal.
+func (a singlePartitionAccessor) Export(ctx context.Context, key, partitionKey string, blob []byte) error {
+	a.accessor.Export(marshalFunc(func() ([]byte, error) {
+		return blob, nil
+	}), key)
+	return nil
+}
+
+// unmarshalFunc adapts a func([]byte) error into a cache.Unmarshaler.
+type unmarshalFunc func([]byte) error
+
+func (f unmarshalFunc) Unmarshal(b []byte) error { return f(b) }
+
+// marshalFunc adapts a func() ([]byte, error) into a cache.Marshaler.
+type marshalFunc func() ([]byte, error)
+
+func (f marshalFunc) Marshal() ([]byte, error) { return f() }
@@ -0,0 +1,262 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+// Package base is the base client shared by the public and confidential package clients: it
+// owns the token cache and knows how to satisfy AcquireTokenSilent from it, falling back to
+// an oauth.Client to get a fresh token on a miss.
+package base
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/cache"
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/cloud"
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth"
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth/ops/accesstokens"
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth/ops/authority"
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/storage"
+	internalTime "github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/time"
+)
+
+// Option configures a Client constructed by New.
+type Option func(*Client)
+
+// Client is the base client shared by the public and confidential package clients.
+type Client struct {
+	AuthParams  authority.AuthParams
+	OAuthClient *oauth.Client
+
+	manager *storage.PartitionedManager
+
+	partitionedAccessor cache.PartitionedCacheAccessor
+
+	clock          internalTime.Clock
+	cloud          cloud.Configuration
+	cacheTelemetry CacheTelemetry
+	dedupeRequests bool
+	requests       *requestGroup
+}
+
+// New creates a Client for clientID authenticating against authorityURL via oauthClient.
+func New(clientID, authorityURL string, oauthClient *oauth.Client, opts ...Option) (Client, error) {
+	if clientID == "" {
+		return Client{}, fmt.Errorf("clientID can't be empty string")
+	}
+	if oauthClient == nil {
+		return Client{}, fmt.Errorf("oauthClient can't be nil")
+	}
+
+	c := Client{
+		OAuthClient:    oauthClient,
+		manager:        storage.NewPartitionedManager(),
+		clock:          internalTime.RealClock{},
+		cloud:          cloud.AzurePublic,
+		dedupeRequests: true,
+		requests:       newRequestGroup(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if oauthClient.Resolver == nil {
+		oauthClient.Resolver = authority.DefaultResolver{}
+	}
+	if oauthClient.Authority == nil {
+		oauthClient.Authority = authority.DefaultAuthority{}
+	}
+
+	c.AuthParams = authority.AuthParams{
+		ClientID:      clientID,
+		AuthorityInfo: authorityURL,
+		Tenant:        tenantFromAuthority(authorityURL),
+		Cloud:         c.cloud,
+	}
+
+	if _, err := oauthClient.Authority.ValidateAuthority(context.Background(), c.AuthParams); err != nil {
+		return Client{}, fmt.Errorf("validating authority: %w", err)
+	}
+
+	endpoints, err := oauthClient.Resolver.ResolveEndpoints(context.Background(), c.AuthParams)
+	if err != nil {
+		return Client{}, err
+	}
+	c.AuthParams.Endpoints = endpoints
+
+	return c, nil
+}
+
+// tenantFromAuthority returns the last path segment of an authority URL, e.g. "tenant" for
+// "https://login.microsoftonline.com/tenant", or "common" if there isn't one.
+func tenantFromAuthority(authorityURL string) string {
+	trimmed := strings.TrimSuffix(authorityURL, "/")
+	if j := strings.Index(trimmed, "://"); j >= 0 {
+		trimmed = trimmed[j+len("://"):]
+	}
+	i := strings.LastIndex(trimmed, "/")
+	if i < 0 || i == len(trimmed)-1 {
+		return "common"
+	}
+	return trimmed[i+1:]
+}
+
+// AcquireTokenSilentParameters are the parameters for AcquireTokenSilent.
+type AcquireTokenSilentParameters struct {
+	Scopes      []string
+	RequestType accesstokens.RequestType
+	Credential  *accesstokens.Credential
+	IsAppCache  bool
+}
+
+// AuthResult is the result of a token acquisition, whether satisfied from the cache or
+// refreshed over the network.
+type AuthResult struct {
+	AccessToken   string
+	ExpiresOn     time.Time
+	GrantedScopes []string
+}
+
+// scopeKey returns a canonical, order-independent key for a set of scopes, used to look a
+// token up within a cache partition.
+func scopeKey(scopes []string) string {
+	sorted := append([]string(nil), scopes...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, " ")
+}
+
+// partitionKeyFor derives this client's cache partition key for the app cache (tenant + home
+// account ID) or the user cache (client ID + a hash of the requested scopes), so two
+// AcquireTokenSilent calls for different scopes never collide in the same user-cache partition.
+func (c *Client) partitionKeyFor(isAppCache bool, scopes []string) string {
+	scopeHash := sha256.Sum256([]byte(scopeKey(scopes)))
+	return suggestedPartitionKey(c.cloud.Name, c.AuthParams.Tenant, c.AuthParams.HomeAccountID, c.AuthParams.ClientID, hex.EncodeToString(scopeHash[:]), isAppCache)
+}
+
+// requestKey identifies this client's (client ID, authority) pair to the cache accessor's
+// key parameter, which the accessor can use as a lookup key, ignore, or fold into its own
+// scheme alongside the partition key.
+func (c *Client) requestKey() string {
+	return c.AuthParams.ClientID + "|" + c.AuthParams.AuthorityInfo
+}
+
+// AcquireTokenSilent returns a token from the cache, refreshing it via OAuthClient on a miss.
+// Concurrent calls that share an authority, account, credential, and scope set are coalesced
+// per c.dedupeRequests; see dedupe.go.
+func (c *Client) AcquireTokenSilent(ctx context.Context, params AcquireTokenSilentParameters) (AuthResult, error) {
+	key := requestDedupeKey(c.AuthParams.HomeAccountID, c.AuthParams.AuthorityInfo, params.Scopes, credentialHash(params.Credential), params.IsAppCache)
+	return c.dedupe(ctx, key, func(ctx context.Context) (AuthResult, error) {
+		return c.acquireTokenSilent(ctx, params)
+	})
+}
+
+// acquireTokenSilent is the single-attempt body AcquireTokenSilent runs at most once per
+// dedupe key among concurrently-waiting callers.
+func (c *Client) acquireTokenSilent(ctx context.Context, params AcquireTokenSilentParameters) (AuthResult, error) {
+	partitionKey := c.partitionKeyFor(params.IsAppCache, params.Scopes)
+
+	if c.partitionedAccessor != nil {
+		start := time.Now()
+		blob, err := c.partitionedAccessor.Replace(ctx, c.requestKey(), partitionKey)
+		c.telemetry().OnReplace(c.requestKey(), partitionKey, len(blob), time.Since(start), err)
+		if err != nil {
+			return AuthResult{}, fmt.Errorf("replacing cache from partition %q: %w", partitionKey, err)
+		}
+		if err := c.manager.Unmarshal(partitionKey, blob); err != nil {
+			return AuthResult{}, fmt.Errorf("unmarshaling cache partition %q: %w", partitionKey, err)
+		}
+	}
+
+	sk := scopeKey(params.Scopes)
+	at, ok, missReason := c.manager.Read(partitionKey, sk, c.clock.Now())
+	if ok {
+		c.telemetry().OnCacheHit(c.AuthParams, "access_token")
+		return AuthResult{AccessToken: at.Secret, ExpiresOn: at.ExpiresOn, GrantedScopes: params.Scopes}, nil
+	}
+	c.telemetry().OnCacheMiss(c.AuthParams, missReason)
+
+	tr, err := c.refresh(ctx, params)
+	if err != nil {
+		return AuthResult{}, err
+	}
+
+	return c.cacheTokenResponse(ctx, partitionKey, sk, tr)
+}
+
+// credentialHash returns a stable, opaque identifier for cred so two AcquireTokenSilent calls
+// authenticating with different credentials never share a dedupe key, without putting a
+// secret or assertion into that key.
+func credentialHash(cred *accesstokens.Credential) string {
+	if cred == nil {
+		return ""
+	}
+	h := sha256.New()
+	h.Write([]byte(cred.Secret))
+	h.Write([]byte{0})
+	h.Write([]byte(cred.Assertion))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// refresh gets a fresh token from OAuthClient when the cache can't satisfy the request.
+// params.Credential is resolved into request body parameters (client secret or client
+// assertion) immediately before the call, so a federated credential with a short-lived
+// assertion is never reused past the request that fetched it.
+func (c *Client) refresh(ctx context.Context, params AcquireTokenSilentParameters) (accesstokens.TokenResponse, error) {
+	if params.Credential == nil {
+		return accesstokens.TokenResponse{}, fmt.Errorf("AcquireTokenSilentParameters.Credential can't be nil")
+	}
+	body := url.Values{}
+	params.Credential.AddToRequest(body)
+	return c.OAuthClient.AccessTokens.FromClientCredential(ctx, c.AuthParams, body)
+}
+
+// cacheTokenResponse writes tr into partitionKey under sk and, if a partitioned accessor is
+// configured, exports just that partition.
+func (c *Client) cacheTokenResponse(ctx context.Context, partitionKey, sk string, tr accesstokens.TokenResponse) (AuthResult, error) {
+	c.manager.Write(partitionKey, sk, storage.AccessToken{Secret: tr.AccessToken, ExpiresOn: tr.ExpiresOn.T})
+
+	if c.partitionedAccessor != nil {
+		blob, err := c.manager.Marshal(partitionKey)
+		if err != nil {
+			return AuthResult{}, fmt.Errorf("marshaling cache partition %q: %w", partitionKey, err)
+		}
+		start := time.Now()
+		err = c.partitionedAccessor.Export(ctx, c.requestKey(), partitionKey, blob)
+		if err != nil {
+			return AuthResult{}, fmt.Errorf("exporting cache partition %q: %w", partitionKey, err)
+		}
+		c.telemetry().OnExport(c.requestKey(), partitionKey, len(blob), time.Since(start))
+	}
+
+	return AuthResult{AccessToken: tr.AccessToken, ExpiresOn: tr.ExpiresOn.T, GrantedScopes: tr.GrantedScopes.Slice}, nil
+}
+
+// AuthResultFromToken builds an AuthResult directly from a token response obtained out of
+// band (e.g. from a client credentials grant already performed by the caller), caching it
+// the same way a refreshed token is cached when cacheWrite is true: the request's partition is
+// first loaded from the partitioned accessor, if any, so the token is added to it rather than
+// replacing it.
+func (c *Client) AuthResultFromToken(ctx context.Context, authParams authority.AuthParams, tr accesstokens.TokenResponse, cacheWrite bool) (AuthResult, error) {
+	if !cacheWrite {
+		return AuthResult{AccessToken: tr.AccessToken, ExpiresOn: tr.ExpiresOn.T, GrantedScopes: tr.GrantedScopes.Slice}, nil
+	}
+	isAppCache := authParams.AuthorizationType == authority.ATClientCredentials
+	partitionKey := c.partitionKeyFor(isAppCache, tr.GrantedScopes.Slice)
+	if c.partitionedAccessor != nil {
+		start := time.Now()
+		blob, err := c.partitionedAccessor.Replace(ctx, c.requestKey(), partitionKey)
+		c.telemetry().OnReplace(c.requestKey(), partitionKey, len(blob), time.Since(start), err)
+		if err != nil {
+			return AuthResult{}, fmt.Errorf("replacing cache from partition %q: %w", partitionKey, err)
+		}
+		if err := c.manager.Unmarshal(partitionKey, blob); err != nil {
+			return AuthResult{}, fmt.Errorf("unmarshaling cache partition %q: %w", partitionKey, err)
+		}
+	}
+	return c.cacheTokenResponse(ctx, partitionKey, scopeKey(tr.GrantedScopes.Slice), tr)
+}
@@ -0,0 +1,17 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package base
+
+import internalTime "github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/time"
+
+// WithClock overrides the Clock base.Client uses to decide whether a cached access token has
+// expired. That is the only check it drives: base.Client caches no refresh tokens and no
+// instance discovery metadata, so neither has an expiry to check. It defaults to
+// internalTime.RealClock and exists so tests can exercise "token expires in N seconds" behavior deterministically,
+// without sleeping or racing real wall-clock time.
+func WithClock(clock internalTime.Clock) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
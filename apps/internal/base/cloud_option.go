@@ -0,0 +1,16 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package base
+
+import "github.com/AzureAD/microsoft-authentication-library-for-go/apps/cloud"
+
+// WithCloud configures the cloud.Configuration base.Client uses for instance discovery,
+// authority validation, and token requests, instead of assuming the public Azure cloud. The
+// Resolver and Authority implementations behind Client read these endpoints off
+// AuthParams.Cloud rather than hardcoding login.microsoftonline.com.
+func WithCloud(c cloud.Configuration) Option {
+	return func(client *Client) {
+		client.cloud = c
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package base
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// requestDedupeKey builds the canonical key used to coalesce concurrent AcquireTokenSilent
+// calls that would otherwise perform the same cache lookup and, on a miss, the same refresh.
+// Two calls share a key only if they agree on cache (app vs. user), authority, client
+// credential, and the (order-independent) scope set.
+func requestDedupeKey(homeAccountID, authority string, scopes []string, credentialHash string, isAppCache bool) string {
+	sorted := append([]string(nil), scopes...)
+	sort.Strings(sorted)
+
+	h := sha256.New()
+	h.Write([]byte(homeAccountID))
+	h.Write([]byte{0})
+	h.Write([]byte(authority))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.Join(sorted, ",")))
+	h.Write([]byte{0})
+	h.Write([]byte(credentialHash))
+	if isAppCache {
+		h.Write([]byte{1})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// leaderCall is the shared state for one in-flight dedupe key: a context all waiters'
+// Do calls run against, and how many of them are still waiting.
+type leaderCall struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	waiting int
+}
+
+// requestGroup coalesces concurrent AcquireTokenSilent calls sharing a dedupe key into a
+// single cache lookup / refresh attempt, fanning the result out to every waiter via
+// singleflight.Group. Each waiter can still abandon the call on its own context being
+// canceled without disturbing the others; the shared call itself is only canceled once every
+// waiter for that key has left, whether by canceling or by receiving a result.
+type requestGroup struct {
+	sf singleflight.Group
+
+	mu    sync.Mutex
+	calls map[string]*leaderCall
+}
+
+func newRequestGroup() *requestGroup {
+	return &requestGroup{calls: make(map[string]*leaderCall)}
+}
+
+// join registers the caller as waiting on key and returns the (possibly new) shared call.
+func (g *requestGroup) join(key string) *leaderCall {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	lc, ok := g.calls[key]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		lc = &leaderCall{ctx: ctx, cancel: cancel}
+		g.calls[key] = lc
+	}
+	lc.waiting++
+	return lc
+}
+
+// leave unregisters the caller from key. Once the last waiter leaves, the shared call's
+// context is canceled and its entry is removed so a later call for the same key starts fresh.
+func (g *requestGroup) leave(key string, lc *leaderCall) {
+	g.mu.Lock()
+	lc.waiting--
+	done := lc.waiting == 0
+	if done {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+	if done {
+		lc.cancel()
+	}
+}
+
+// Do runs fn at most once per key among concurrently-waiting callers and fans its result out
+// to all of them. fn runs with a context shared by every caller currently waiting on key, so
+// it keeps running even if the caller that happened to start it cancels, as long as another
+// caller is still waiting on the result.
+func (g *requestGroup) Do(ctx context.Context, key string, fn func(context.Context) (AuthResult, error)) (AuthResult, error) {
+	lc := g.join(key)
+	defer g.leave(key, lc)
+
+	// The singleflight key names this shared call, not just key, so a caller arriving after
+	// every earlier waiter has left never joins a call still running on a canceled context.
+	resultCh := g.sf.DoChan(fmt.Sprintf("%s/%p", key, lc), func() (interface{}, error) {
+		return fn(lc.ctx)
+	})
+
+	select {
+	case res := <-resultCh:
+		if res.Err != nil {
+			return AuthResult{}, res.Err
+		}
+		return res.Val.(AuthResult), nil
+	case <-ctx.Done():
+		return AuthResult{}, ctx.Err()
+	}
+}
+
+// dedupe wraps fn, a single AcquireTokenSilent attempt, with request deduplication when
+// c.dedupeRequests is enabled (the default). Callers that disable it via
+// WithRequestDeduplication(false) get strict per-call semantics: every call performs its own
+// cache lookup and, on a miss, its own refresh.
+func (c *Client) dedupe(ctx context.Context, key string, fn func(context.Context) (AuthResult, error)) (AuthResult, error) {
+	if !c.dedupeRequests {
+		return fn(ctx)
+	}
+	return c.requests.Do(ctx, key, fn)
+}
@@ -0,0 +1,15 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package base
+
+// WithRequestDeduplication controls whether concurrent AcquireTokenSilent calls that share
+// the same authority, client credential, scopes, and account are coalesced into a single
+// cache lookup / refresh attempt. It defaults to enabled. Disable it if your application
+// requires strict per-call semantics, e.g. each call must independently observe cache writes
+// made by calls that started after it but finished first.
+func WithRequestDeduplication(enabled bool) Option {
+	return func(c *Client) {
+		c.dedupeRequests = enabled
+	}
+}
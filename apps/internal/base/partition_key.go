@@ -0,0 +1,18 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package base
+
+import "strings"
+
+// suggestedPartitionKey builds the partition key base.Client passes to
+// cache.PartitionedCacheAccessor as suggestedPartitionKey/partitionKey. It's derived from
+// tenant and home account ID for the app cache, or client ID and a hash of the requested
+// scopes for the user cache, always prefixed with the cloud name so that, for example, an
+// AzurePublic cache and an AzureGovernment cache for the same tenant never collide.
+func suggestedPartitionKey(cloudName, tenant, homeAccountID, clientID, scopeHash string, isAppCache bool) string {
+	if isAppCache {
+		return strings.Join([]string{cloudName, tenant, homeAccountID}, ".")
+	}
+	return strings.Join([]string{cloudName, clientID, scopeHash}, ".")
+}
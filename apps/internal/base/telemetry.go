@@ -0,0 +1,57 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package base
+
+import (
+	"time"
+
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth/ops/authority"
+)
+
+// CacheTelemetry lets an application observe MSAL's cache behavior, e.g. to export it as
+// OpenTelemetry metrics or Prometheus counters, without forking the library or wrapping every
+// call site itself. All methods are called synchronously from the request path; a slow
+// implementation will add latency to AcquireTokenSilent.
+type CacheTelemetry interface {
+	// OnReplace is called after a PartitionedCacheAccessor.Replace, whether or not it
+	// succeeded. bytes is the size of the blob read, 0 if the partition was empty.
+	OnReplace(key, partition string, bytes int, dur time.Duration, err error)
+	// OnExport is called after a PartitionedCacheAccessor.Export that succeeded.
+	OnExport(key, partition string, bytes int, dur time.Duration)
+	// OnCacheHit is called when AcquireTokenSilent finds a usable token without contacting
+	// the network. source identifies which credential satisfied the request; it's always
+	// "access_token", the only kind of token the cache holds.
+	OnCacheHit(authParams authority.AuthParams, source string)
+	// OnCacheMiss is called when AcquireTokenSilent can't satisfy the request from the
+	// cache and must fall back to a network round trip. reason is a short, stable string,
+	// either "no_token" (no cached entry for this partition and scope set) or "expired"
+	// (a cached entry exists but is past its ExpiresOn).
+	OnCacheMiss(authParams authority.AuthParams, reason string)
+}
+
+// WithCacheTelemetry registers sink to receive cache-event callbacks from
+// AcquireTokenSilent and the cache accessor call sites. Only one sink may be registered; the
+// last call to WithCacheTelemetry wins.
+func WithCacheTelemetry(sink CacheTelemetry) Option {
+	return func(c *Client) {
+		c.cacheTelemetry = sink
+	}
+}
+
+// telemetry returns c.cacheTelemetry, or a no-op sink if none was registered, so call sites
+// don't need a nil check before invoking it.
+func (c *Client) telemetry() CacheTelemetry {
+	if c.cacheTelemetry != nil {
+		return c.cacheTelemetry
+	}
+	return noopCacheTelemetry{}
+}
+
+type noopCacheTelemetry struct{}
+
+func (noopCacheTelemetry) OnReplace(key, partition string, bytes int, dur time.Duration, err error) {
+}
+func (noopCacheTelemetry) OnExport(key, partition string, bytes int, dur time.Duration) {}
+func (noopCacheTelemetry) OnCacheHit(authParams authority.AuthParams, source string)     {}
+func (noopCacheTelemetry) OnCacheMiss(authParams authority.AuthParams, reason string)    {}
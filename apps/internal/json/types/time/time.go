@@ -0,0 +1,14 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+// Package time holds time.Time wrapper types for the expiry fields in token responses.
+package time
+
+import "time"
+
+// DurationTime wraps the time.Time of an expiry field such as a token response's ExpiresOn,
+// which AAD sends as a number of seconds. It defines no JSON (un)marshaling of its own;
+// callers set and read T directly.
+type DurationTime struct {
+	T time.Time
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+// Package fake holds test doubles for the oauth package's interfaces, so tests and the perf
+// harness can exercise base.Client without making real network calls.
+package fake
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth/ops/accesstokens"
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth/ops/authority"
+)
+
+// AccessTokens is a fake accesstokens.Provider that always returns AccessToken, regardless of
+// the credential or scopes requested.
+type AccessTokens struct {
+	AccessToken accesstokens.TokenResponse
+
+	// LastRequestBody is the body of the most recent FromClientCredential call, so a test can
+	// assert on the client_secret or client_assertion/client_assertion_type a
+	// confidential.Credential actually produced, without standing up a real token endpoint.
+	LastRequestBody url.Values
+}
+
+// FromClientCredential records body in f.LastRequestBody and returns f.AccessToken.
+func (f *AccessTokens) FromClientCredential(ctx context.Context, authParams authority.AuthParams, body url.Values) (accesstokens.TokenResponse, error) {
+	f.LastRequestBody = body
+	return f.AccessToken, nil
+}
+
+// Authority is a fake oauth.Authority that always returns InstanceResp.
+type Authority struct {
+	InstanceResp authority.InstanceDiscoveryResponse
+}
+
+// ValidateAuthority returns f.InstanceResp.
+func (f *Authority) ValidateAuthority(ctx context.Context, authParams authority.AuthParams) (authority.InstanceDiscoveryResponse, error) {
+	return f.InstanceResp, nil
+}
+
+// ResolveEndpoints is a fake oauth.Resolver that always returns Endpoints.
+type ResolveEndpoints struct {
+	Endpoints authority.Endpoints
+}
+
+// ResolveEndpoints returns f.Endpoints.
+func (f *ResolveEndpoints) ResolveEndpoints(ctx context.Context, authParams authority.AuthParams) (authority.Endpoints, error) {
+	return f.Endpoints, nil
+}
+
+// WSTrust is a fake oauth.WSTrust. The client credentials flow never calls it, so it has no
+// behavior to fake.
+type WSTrust struct{}
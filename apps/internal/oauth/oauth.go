@@ -0,0 +1,38 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+// Package oauth is MSAL's OAuth layer: it validates authorities, resolves their endpoints,
+// and exchanges credentials for tokens. It holds no cache state itself; base.Client owns the
+// cache and calls into a Client here only on a cache miss.
+package oauth
+
+import (
+	"context"
+
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth/ops/accesstokens"
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth/ops/authority"
+)
+
+// Authority validates an authority and discovers its aliases.
+type Authority interface {
+	ValidateAuthority(ctx context.Context, authParams authority.AuthParams) (authority.InstanceDiscoveryResponse, error)
+}
+
+// Resolver resolves the OAuth endpoints for an authority.
+type Resolver interface {
+	ResolveEndpoints(ctx context.Context, authParams authority.AuthParams) (authority.Endpoints, error)
+}
+
+// WSTrust exchanges a WS-Trust token for a SAML assertion, used by the resource owner
+// password and integrated Windows auth flows. The client credentials flow never calls it; the
+// field exists so Client has the same shape regardless of which flow an application uses.
+type WSTrust interface{}
+
+// Client is MSAL's OAuth layer. base.New requires one so base.Client can be pointed at a fake
+// implementation in tests instead of making real network calls.
+type Client struct {
+	AccessTokens accesstokens.Provider
+	Authority    Authority
+	Resolver     Resolver
+	WSTrust      WSTrust
+}
@@ -0,0 +1,44 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+// Package accesstokens holds the types used to request and represent access tokens.
+package accesstokens
+
+import (
+	"context"
+	"net/url"
+
+	internalTime "github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/json/types/time"
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth/ops/authority"
+)
+
+// RequestType identifies which OAuth flow a token request uses.
+type RequestType int
+
+const (
+	_ RequestType = iota
+	// ATConfidential is a token request made by a confidential client.
+	ATConfidential
+)
+
+// Scopes is the set of scopes granted to, or requested for, a token.
+type Scopes struct {
+	Slice []string
+}
+
+// TokenResponse is AAD's token endpoint response (or a fake standing in for it in tests).
+type TokenResponse struct {
+	AccessToken   string
+	ExpiresOn     internalTime.DurationTime
+	GrantedScopes Scopes
+}
+
+// Provider exchanges a client credential for an access token. body carries the credential's
+// request parameters (client_secret or client_assertion/client_assertion_type), already
+// populated by Credential.AddToRequest, so a Provider never needs to branch on which kind of
+// credential the caller used. oauth.Client.AccessTokens is a Provider in production;
+// fake.AccessTokens is a test double that returns a canned TokenResponse regardless of what's
+// requested.
+type Provider interface {
+	FromClientCredential(ctx context.Context, authParams authority.AuthParams, body url.Values) (TokenResponse, error)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package accesstokens
+
+import "net/url"
+
+// AssertionType values are sent as the client_assertion_type request parameter when
+// Credential.Assertion is set.
+const (
+	// AssertionTypeJWTBearer is the assertion type used for client assertions (JWTs),
+	// including federated credentials from a workload identity provider.
+	AssertionTypeJWTBearer = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+)
+
+// Credential authenticates a confidential client's token requests. Exactly one of Secret or
+// Assertion should be set; Assertion takes precedence if both are.
+type Credential struct {
+	// Secret is the client secret.
+	Secret string
+
+	// Assertion is a client assertion (JWT), either a static one or one obtained immediately
+	// before the request via a confidential.Credential created with
+	// NewCredFromAssertionCallback.
+	Assertion string
+
+	// AssertionType is sent as client_assertion_type alongside Assertion. It's always
+	// AssertionTypeJWTBearer today; the field exists so new assertion types don't require an
+	// API change.
+	AssertionType string
+}
+
+// AddToRequest adds this credential's parameters to a token request body.
+func (c *Credential) AddToRequest(v url.Values) {
+	if c.Assertion != "" {
+		v.Set("client_assertion", c.Assertion)
+		v.Set("client_assertion_type", c.AssertionType)
+		return
+	}
+	v.Set("client_secret", c.Secret)
+}
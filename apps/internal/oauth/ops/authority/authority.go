@@ -0,0 +1,68 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+// Package authority holds the types describing an AAD authority and the parameters needed to
+// request a token from it.
+package authority
+
+import (
+	"strings"
+
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/cloud"
+)
+
+// AuthorizationType describes the OAuth grant a request uses.
+type AuthorizationType int
+
+const (
+	_ AuthorizationType = iota
+	// ATClientCredentials is the client credentials grant, used by confidential clients to
+	// acquire a token for themselves rather than on behalf of a user.
+	ATClientCredentials
+)
+
+// Endpoints holds the OAuth endpoints for an authority.
+type Endpoints struct {
+	AuthorizationEndpoint string
+	TokenEndpoint         string
+}
+
+// InstanceDiscoveryMetadata describes one group of equivalent authority aliases returned by
+// instance discovery.
+type InstanceDiscoveryMetadata struct {
+	PreferredNetwork string
+	Aliases          []string
+}
+
+// InstanceDiscoveryResponse is the result of validating an authority via instance discovery.
+type InstanceDiscoveryResponse struct {
+	Metadata []InstanceDiscoveryMetadata
+}
+
+// AuthParams holds the parameters needed to request, cache, and refresh a token for a given
+// client and authority.
+type AuthParams struct {
+	ClientID      string
+	AuthorityInfo string // the authority URL, e.g. "https://login.microsoftonline.com/tenant"
+	Tenant        string
+	HomeAccountID string
+	Scopes        []string
+
+	AuthorizationType AuthorizationType
+	Endpoints         Endpoints
+	Cloud             cloud.Configuration
+}
+
+// DefaultEndpoints derives this authority's OAuth endpoints from its configured cloud. It's
+// what DefaultResolver uses; tests instead inject fake.ResolveEndpoints with fixed values.
+func (p AuthParams) DefaultEndpoints() Endpoints {
+	host := strings.TrimSuffix(p.Cloud.ActiveDirectoryAuthorityHost, "/")
+	tenant := p.Tenant
+	if tenant == "" {
+		tenant = "common"
+	}
+	return Endpoints{
+		AuthorizationEndpoint: host + "/" + tenant + "/oauth2/v2.0/authorize",
+		TokenEndpoint:         host + "/" + tenant + "/oauth2/v2.0/token",
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+package authority
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultResolver is the production oauth.Resolver: it derives an authority's endpoints from
+// its configured cloud.Configuration (AzurePublic unless base.WithCloud says otherwise)
+// instead of a hardcoded Microsoft cloud host. base.New uses it unless the caller supplied
+// an oauth.Client with its own Resolver, which is how tests substitute fake.ResolveEndpoints.
+type DefaultResolver struct{}
+
+// ResolveEndpoints returns authParams.DefaultEndpoints().
+func (DefaultResolver) ResolveEndpoints(ctx context.Context, authParams AuthParams) (Endpoints, error) {
+	return authParams.DefaultEndpoints(), nil
+}
+
+// DefaultAuthority is the production oauth.Authority: it skips validation entirely for clouds
+// that set DisableInstanceDiscovery, and otherwise checks only that the cloud has an
+// InstanceDiscoveryEndpoint configured. It never sends a request to that endpoint. base.New
+// uses it unless the caller supplied an oauth.Client with its own Authority, which is how
+// tests substitute fake.Authority.
+type DefaultAuthority struct{}
+
+// ValidateAuthority returns an empty InstanceDiscoveryResponse for clouds that disable
+// instance discovery, or an error if discovery is required but no endpoint is configured.
+func (DefaultAuthority) ValidateAuthority(ctx context.Context, authParams AuthParams) (InstanceDiscoveryResponse, error) {
+	if authParams.Cloud.DisableInstanceDiscovery {
+		return InstanceDiscoveryResponse{}, nil
+	}
+	if authParams.Cloud.InstanceDiscoveryEndpoint == "" {
+		return InstanceDiscoveryResponse{}, fmt.Errorf("cloud %q has no instance discovery endpoint configured", authParams.Cloud.Name)
+	}
+	return InstanceDiscoveryResponse{}, nil
+}
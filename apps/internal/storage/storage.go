@@ -0,0 +1,100 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+// Package storage is base.Client's in-memory token store.
+package storage
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// AccessToken is a single cached access token.
+type AccessToken struct {
+	Secret    string    `json:"secret"`
+	ExpiresOn time.Time `json:"expires_on"`
+}
+
+// Partition holds every access token cached under one partition key (e.g. one tenant, for
+// the app cache), keyed by the sorted, space-joined scopes each token was granted for.
+type Partition struct {
+	AccessTokens map[string]AccessToken `json:"access_tokens"`
+}
+
+// PartitionedManager is the in-memory token store base.Client reads and writes directly. It's
+// synced to/from external storage via cache.PartitionedCacheAccessor.Replace/Export, one
+// partition at a time, so populating or reading N tokens spread evenly across T partitions
+// costs O(N^2/T) (de)serialization work rather than the O(N^2) a whole-cache
+// Marshaler/Unmarshaler would.
+type PartitionedManager struct {
+	mu         sync.Mutex
+	partitions map[string]*Partition
+}
+
+// NewPartitionedManager returns an empty PartitionedManager.
+func NewPartitionedManager() *PartitionedManager {
+	return &PartitionedManager{partitions: make(map[string]*Partition)}
+}
+
+// Read looks up the access token cached for scopeKey within partitionKey. The bool result is
+// false if there's no such entry or the cached token has already expired as of now; reason
+// distinguishes the two cases as "no_token" or "expired", and is "" on a hit.
+func (m *PartitionedManager) Read(partitionKey, scopeKey string, now time.Time) (AccessToken, bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.partitions[partitionKey]
+	if !ok {
+		return AccessToken{}, false, "no_token"
+	}
+	at, ok := p.AccessTokens[scopeKey]
+	if !ok {
+		return AccessToken{}, false, "no_token"
+	}
+	if !at.ExpiresOn.After(now) {
+		return AccessToken{}, false, "expired"
+	}
+	return at, true, ""
+}
+
+// Write caches at for scopeKey within partitionKey.
+func (m *PartitionedManager) Write(partitionKey, scopeKey string, at AccessToken) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p, ok := m.partitions[partitionKey]
+	if !ok {
+		p = &Partition{AccessTokens: make(map[string]AccessToken)}
+		m.partitions[partitionKey] = p
+	}
+	p.AccessTokens[scopeKey] = at
+}
+
+// Marshal serializes only the partition named by partitionKey, not the whole cache.
+func (m *PartitionedManager) Marshal(partitionKey string) ([]byte, error) {
+	m.mu.Lock()
+	p, ok := m.partitions[partitionKey]
+	m.mu.Unlock()
+	if !ok {
+		p = &Partition{}
+	}
+	return json.Marshal(p)
+}
+
+// Unmarshal replaces only the partition named by partitionKey with the contents of blob. An
+// empty blob clears that partition instead of erroring, so a first-use cache miss behaves the
+// same as an explicitly empty partition.
+func (m *PartitionedManager) Unmarshal(partitionKey string, blob []byte) error {
+	p := &Partition{AccessTokens: make(map[string]AccessToken)}
+	if len(blob) > 0 {
+		if err := json.Unmarshal(blob, p); err != nil {
+			return err
+		}
+		if p.AccessTokens == nil {
+			p.AccessTokens = make(map[string]AccessToken)
+		}
+	}
+	m.mu.Lock()
+	m.partitions[partitionKey] = p
+	m.mu.Unlock()
+	return nil
+}
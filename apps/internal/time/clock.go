@@ -0,0 +1,24 @@
+// Copyright (c) Microsoft Corporation.
+// Licensed under the MIT license.
+
+// Package time holds the Clock abstraction used wherever MSAL needs the current time to
+// decide whether a cached token is still usable, so that behavior depending on elapsed time
+// (silent refresh of an expired access token) can be tested deterministically.
+package time
+
+import "time"
+
+// Clock provides the current time. Production code should use RealClock; tests can supply a
+// fake implementation to control what "now" is without sleeping or racing real wall-clock
+// time.
+type Clock interface {
+	Now() time.Time
+}
+
+// RealClock is the default Clock, backed by time.Now.
+type RealClock struct{}
+
+// Now returns time.Now().
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"sort"
 	"strconv"
+	"sync"
 	"text/template"
 	"time"
 
@@ -18,10 +19,56 @@ import (
 	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth/fake"
 	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth/ops/accesstokens"
 	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/oauth/ops/authority"
+	fakeclock "github.com/AzureAD/microsoft-authentication-library-for-go/apps/internal/time"
 	inmemory "github.com/patrickmn/go-cache"
 )
 
-var cacheAccessor = &TokenCache{cache: inmemory.New(5*time.Minute, 10*time.Minute)}
+// cacheAccessor is partitioned by tenant so that populating the cache across many tenants
+// only ever (de)serializes the tokens for the tenant being written, instead of the whole
+// cache, as the single-blob TokenCache example would.
+var cacheAccessor = &PartitionedTokenCache{cache: inmemory.New(5*time.Minute, 10*time.Minute)}
+
+// clock is fixed for the duration of the run so every token's ExpiresOn is computed relative
+// to the same instant, not whatever time.Now() happens to return when each token is seeded.
+// This keeps expiration checks deterministic and decoupled from the wall-clock durations the
+// harness is actually measuring.
+var clock = &fakeTestClock{fixed: time.Now()}
+
+type fakeTestClock struct{ fixed time.Time }
+
+func (c *fakeTestClock) Now() time.Time { return c.fixed }
+
+var _ fakeclock.Clock = (*fakeTestClock)(nil)
+
+// perfSink is a base.CacheTelemetry that records Replace/Export durations instead of having
+// the harness wrap every call site with its own time.Now()/Sub pair. Export durations come
+// from population; retrieval finds every seeded token cached and exports nothing. Population
+// also drives Replace, so TestPerformance clears the Replace bucket before retrieval starts.
+type perfSink struct {
+	mu               sync.Mutex
+	exportDurations  []time.Duration
+	replaceDurations []time.Duration
+}
+
+func (s *perfSink) OnExport(key, partition string, bytes int, dur time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exportDurations = append(s.exportDurations, dur)
+}
+
+func (s *perfSink) OnReplace(key, partition string, bytes int, dur time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.replaceDurations = append(s.replaceDurations, dur)
+}
+
+func (s *perfSink) OnCacheHit(authParams authority.AuthParams, source string) {}
+
+func (s *perfSink) OnCacheMiss(authParams authority.AuthParams, reason string) {}
+
+var _ base.CacheTelemetry = (*perfSink)(nil)
+
+var telemetry = &perfSink{}
 
 type testParameters struct {
 	// the number of tenants to use
@@ -38,7 +85,7 @@ func fakeClientwithTenantId(tenantID string) (base.Client, error) {
 		AccessTokens: &fake.AccessTokens{
 			AccessToken: accesstokens.TokenResponse{
 				AccessToken:   accessToken,
-				ExpiresOn:     internalTime.DurationTime{T: time.Now().Add(1 * time.Hour)},
+				ExpiresOn:     internalTime.DurationTime{T: clock.Now().Add(1 * time.Hour)},
 				GrantedScopes: accesstokens.Scopes{Slice: tokenScope},
 			},
 		},
@@ -59,20 +106,18 @@ func fakeClientwithTenantId(tenantID string) (base.Client, error) {
 			},
 		},
 		WSTrust: &fake.WSTrust{},
-	}, base.WithCacheAccessor(cacheAccessor))
+	}, base.WithPartitionedCacheAccessor(cacheAccessor), base.WithClock(clock), base.WithCacheTelemetry(telemetry))
 }
 
 type executionTime struct {
-	start          time.Time
-	end            time.Time
-	durationValues []time.Duration
+	start time.Time
+	end   time.Time
 }
 
-func populateTokenCachePerPartition(params testParameters, durationValuesPopulate []time.Duration) executionTime {
+func populateTokenCachePerPartition(params testParameters) executionTime {
 	fmt.Printf("Populating token cache with %d tokens...", params.TokenCount)
 	start := time.Now()
 	for i := 0; i < params.TokenCount; i++ {
-		start1 := time.Now()
 		client, err := fakeClientwithTenantId(strconv.FormatInt(int64(i%(params.TenantCount)), 10))
 		if err != nil {
 			panic(err)
@@ -84,23 +129,20 @@ func populateTokenCachePerPartition(params testParameters, durationValuesPopulat
 		// each token has a different scope which is what makes them unique
 		_, err = client.AuthResultFromToken(context.Background(), authParams, accesstokens.TokenResponse{
 			AccessToken:   accessToken,
-			ExpiresOn:     internalTime.DurationTime{T: time.Now().Add(1 * time.Hour)},
+			ExpiresOn:     internalTime.DurationTime{T: clock.Now().Add(1 * time.Hour)},
 			GrantedScopes: accesstokens.Scopes{Slice: []string{strconv.FormatInt(int64(i), 10)}},
 		}, true)
 		if err != nil {
 			panic(err)
 		}
-		end1 := time.Now()
-		durationValuesPopulate[i] = end1.Sub(start1)
 	}
-	return executionTime{start: start, end: time.Now(), durationValues: durationValuesPopulate}
+	return executionTime{start: start, end: time.Now()}
 }
 
-func executeTestWithPartitions(params testParameters, durationValues []time.Duration) executionTime {
+func executeTestWithPartitions(params testParameters) executionTime {
 	fmt.Printf("Begin token retrieval.....")
 	start := time.Now()
 	for i := 0; i < params.TokenCount; i++ {
-		start1 := time.Now()
 		client, err := fakeClientwithTenantId(strconv.FormatInt(int64(i%(params.TenantCount)), 10))
 		if err != nil {
 			fmt.Println("Failed while creating a client")
@@ -117,11 +159,8 @@ func executeTestWithPartitions(params testParameters, durationValues []time.Dura
 		if err != nil {
 			fmt.Println(err)
 		}
-		end1 := time.Now()
-		durationValues[i] = end1.Sub(start1)
-
 	}
-	return executionTime{start: start, end: time.Now(), durationValues: durationValues}
+	return executionTime{start: start, end: time.Now()}
 }
 
 // PerfStats is used with statsTemplText for reporting purposes
@@ -159,18 +198,32 @@ Test Results:
 `
 var statsTemplate = template.Must(template.New("stats").Parse(statsTemplTxt))
 
+// percentile returns the p-th percentile (0 < p < 1) of durations, which must already be
+// sorted ascending.
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	idx := int(p*float64(len(durations)) + 0.5)
+	if idx >= len(durations) {
+		idx = len(durations) - 1
+	}
+	return durations[idx]
+}
+
 func TestPerformance() {
 	t := testParameters{
 		TenantCount: 100,
 		TokenCount:  400,
 	}
-	var durationValuesPopulate = make([]time.Duration, t.TokenCount)
-	var durationValues = make([]time.Duration, t.TokenCount)
 
 	fmt.Printf("Test Params: %#v\n", t)
 
-	ptime := populateTokenCachePerPartition(t, durationValuesPopulate)
-	ttime := executeTestWithPartitions(t, durationValues)
+	ptime := populateTokenCachePerPartition(t)
+	telemetry.mu.Lock()
+	telemetry.replaceDurations = nil
+	telemetry.mu.Unlock()
+	ttime := executeTestWithPartitions(t)
 	if err := statsTemplate.Execute(os.Stdout, &PerfStats{
 		popExec: ptime,
 		retExec: ttime,
@@ -179,14 +232,19 @@ func TestPerformance() {
 	}); err != nil {
 		panic(err)
 	}
-	fmt.Println("Populate Statistic")
 
-	sort.Slice(ptime.durationValues, func(i, j int) bool { return ptime.durationValues[i] < ptime.durationValues[j] })
-	fmt.Println("P50", ptime.durationValues[int((0.5*(float64(t.TokenCount)))+0.5)])
-	fmt.Println("P95", ptime.durationValues[int((0.95*(float64(t.TokenCount)))+0.5)])
+	telemetry.mu.Lock()
+	exportDurations := append([]time.Duration(nil), telemetry.exportDurations...)
+	replaceDurations := append([]time.Duration(nil), telemetry.replaceDurations...)
+	telemetry.mu.Unlock()
+
+	fmt.Println("Populate Statistic (cache Export)")
+	sort.Slice(exportDurations, func(i, j int) bool { return exportDurations[i] < exportDurations[j] })
+	fmt.Println("P50", percentile(exportDurations, 0.5))
+	fmt.Println("P95", percentile(exportDurations, 0.95))
 
-	fmt.Println("Retreive Statistic")
-	sort.Slice(ttime.durationValues, func(i, j int) bool { return ttime.durationValues[i] < ttime.durationValues[j] })
-	fmt.Println("P50", ttime.durationValues[int((0.5*(float64(t.TokenCount)))+0.5)])
-	fmt.Println("P95", ttime.durationValues[int((0.95*(float64(t.TokenCount)))+0.5)])
+	fmt.Println("Retreive Statistic (cache Replace)")
+	sort.Slice(replaceDurations, func(i, j int) bool { return replaceDurations[i] < replaceDurations[j] })
+	fmt.Println("P50", percentile(replaceDurations, 0.5))
+	fmt.Println("P95", percentile(replaceDurations, 0.95))
 }
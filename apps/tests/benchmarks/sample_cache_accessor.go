@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/cache"
@@ -37,3 +38,29 @@ func (t *TokenCache) Export(cache cache.Marshaler, key string) {
 	}
 	t.cache.Set(key, data, -1)
 }
+
+// PartitionedTokenCache implements cache.PartitionedCacheAccessor. Unlike TokenCache, which
+// (de)serializes the entire in-memory cache on every call, it stores one blob per partition
+// key, so populating N tokens spread evenly across T partitions costs O(N^2/T) serialization
+// work instead of O(N^2).
+type PartitionedTokenCache struct {
+	cache *inmemory.Cache
+}
+
+func (t *PartitionedTokenCache) Replace(ctx context.Context, key, suggestedPartitionKey string) ([]byte, error) {
+	data, found := t.cache.Get(suggestedPartitionKey)
+	if !found {
+		return nil, nil
+	}
+	buf, ok := data.([]byte)
+	if !ok {
+		log.Println("byte conversion didn't work as expected")
+		return nil, nil
+	}
+	return buf, nil
+}
+
+func (t *PartitionedTokenCache) Export(ctx context.Context, key, partitionKey string, blob []byte) error {
+	t.cache.Set(partitionKey, blob, -1)
+	return nil
+}